@@ -22,13 +22,26 @@ const (
 	outputFormatCSV     = "csv"
 	outputFormatSQLite  = "sqlite"
 	outputFormatSQLite3 = "sqlite3"
+	outputFormatSarif   = "sarif"
 )
 
 const (
 	// Note for gometalinter it must be "File:Line:Column: Msg"
 	//  note space between ": Msg"
-	defaultWriteTmpl = `{{ .Filename }}:{{ .Line }}:{{ .Column }}: corrected "{{ .Original }}" to "{{ .Corrected }}"`
-	defaultReadTmpl  = `{{ .Filename }}:{{ .Line }}:{{ .Column }}: "{{ .Original }}" is a misspelling of "{{ .Corrected }}"`
+	defaultWriteTmpl = `{{ .Filename }}:{{ .Line }}:{{ .Column }}: corrected "{{ .Original }}" to "{{ .Corrected }}"{{ if .Note }} ({{ .Note }}){{ end }}`
+	defaultReadTmpl  = `{{ .Filename }}:{{ .Line }}:{{ .Column }}: "{{ .Original }}" is a misspelling of "{{ .Corrected }}"{{ if .Note }} ({{ .Note }}){{ end }}`
+	// hunspellReadTmpl is used in place of defaultReadTmpl when
+	// -hunspell-dict is set: hunspell mode is list-only (it doesn't
+	// pick a single deterministic correction the way the built-in
+	// typo tables do), so the message reads as "not found" with an
+	// optional best-guess rather than a flat "is a misspelling of ''".
+	hunspellReadTmpl = `{{ .Filename }}:{{ .Line }}:{{ .Column }}: "{{ .Original }}" not found in dictionary{{ if .Corrected }} (did you mean "{{ .Corrected }}"?){{ end }}`
+	// suggestReadTmpl is used in place of defaultReadTmpl/defaultWriteTmpl
+	// when -suggest is set: a single ranked candidate reads the same as
+	// a normal correction, but anything with more than one candidate is
+	// report-only (see Replacer.ReplaceSuggest), so the full ranked list
+	// is surfaced instead of silently collapsing to just .Corrected.
+	suggestReadTmpl = `{{ .Filename }}:{{ .Line }}:{{ .Column }}: "{{ .Original }}"{{ if le (len .Suggestions) 1 }} corrected to "{{ .Corrected }}"{{ else }} may be misspelled (suggestions: {{ range $i, $s := .Suggestions }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}){{ end }}`
 	csvTmpl          = `{{ printf "%q" .Filename }},{{ .Line }},{{ .Column }},{{ .Original }},{{ .Corrected }}`
 	csvHeader        = `file,line,column,typo,corrected`
 	sqliteTmpl       = `INSERT INTO misspell VALUES({{ printf "%q" .Filename }},{{ .Line }},{{ .Column }},{{ printf "%q" .Original }},{{ printf "%q" .Corrected }});`
@@ -61,15 +74,21 @@ func main() {
 		writeit      = flag.Bool("w", false, "Overwrite file with corrections (default is just to display)")
 		quietFlag    = flag.Bool("q", false, "Do not emit misspelling output")
 		outFlag      = flag.String("o", "stdout", "output file or [stderr|stdout|]")
-		format       = flag.String("f", "", "'csv', 'sqlite3' or custom Golang template for output")
+		format       = flag.String("f", "", "'csv', 'sqlite3', 'sarif' or custom Golang template for output")
 		ignores      = flag.String("i", "", "ignore the following corrections, comma-separated")
-		userDictPath = flag.String("dict", "", "User defined corrections file path (.csv). CSV format: typo,fix")
-		locale       = flag.String("locale", "", "Correct spellings using locale preferences for US or UK.  Default is to use a neutral variety of English.  Setting locale to US will correct the British spelling of 'colour' to 'color'")
-		mode         = flag.String("source", "text", "Source mode: text (default), go (comments only)")
+		userDictPath = flag.String("dict", "", "User defined corrections file path. CSV format: typo,fix. Also accepts .json, .toml, or .yaml/.yml for structured entries with optional locale restriction and disabled built-ins")
+		locale       = flag.String("locale", "", "Correct spellings using locale preferences for US, UK, NZ, AU or CA.  Default is to use a neutral variety of English.  Setting locale to US will correct the British spelling of 'colour' to 'color'")
+		mode         = flag.String("source", "text", "Source mode: text (default), go (AST-aware, see -go-scope)")
+		goScope      = flag.String("go-scope", "", "Comma-separated AST scopes to check in -source=go mode: comments,strings,identifiers,names (default comments)")
 		debugFlag    = flag.Bool("debug", false, "Debug matching, very slow")
 		exitError    = flag.Bool("error", false, "Exit with 2 if misspelling found")
 		showVersion  = flag.Bool("v", false, "Show version and exit")
 
+		hunspellPath = flag.String("hunspell-path", ".:/usr/local/share/hunspell:/usr/share/hunspell", "Colon-separated search path for Hunspell .aff/.dic dictionaries")
+		hunspellDict = flag.String("hunspell-dict", "", "Hunspell dictionary name to load from -hunspell-path, e.g. en_US")
+		personalPath = flag.String("p", "", "Personal Hunspell wordlist, one word per line, used with -hunspell-dict")
+		suggestFlag  = flag.Bool("suggest", false, "Rank candidate corrections for words missing from the typo table instead of only applying exact matches")
+
 		showLegal = flag.Bool("legal", false, "Show legal information and exit")
 	)
 	flag.Parse()
@@ -106,6 +125,11 @@ func main() {
 		log.Fatalf("Mode must be one of auto=guess, go=golang source, text=plain or markdown-like text")
 	}
 
+	scope, err := misspell.ParseGoScope(*goScope)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	debug = newDebugLogger(*debugFlag)
 
 	r := misspell.Replacer{
@@ -123,22 +147,49 @@ func main() {
 		r.AddRuleList(misspell.DictAmerican)
 	case "UK", "GB":
 		r.AddRuleList(misspell.DictBritish)
-	case "NZ", "AU", "CA":
-		log.Fatalf("Help wanted.")
+	case "NZ":
+		r.AddRuleList(misspell.DictBritish)
+		r.AddRuleList(misspell.DictNewZealand)
+	case "AU":
+		r.AddRuleList(misspell.DictBritish)
+		r.AddRuleList(misspell.DictAustralian)
+	case "CA":
+		r.AddRuleList(misspell.DictCanadian)
 	default:
 		log.Fatalf("Unknown locale: %q", *locale)
 	}
 
+	//
+	// Hunspell dictionary (full spellcheck against an external .aff/.dic pair)
+	//
+	var hunspell *misspell.HunspellDict
+	if *hunspellDict != "" {
+		var err error
+		hunspell, err = misspell.LoadHunspellDict(*hunspellPath, *hunspellDict)
+		if err != nil {
+			log.Fatalf("loading hunspell dictionary: %v", err)
+		}
+
+		if *personalPath != "" {
+			if err := hunspell.AddPersonalWordList(*personalPath); err != nil {
+				log.Fatalf("loading personal wordlist: %v", err)
+			}
+		}
+	}
+
 	//
 	// Load user defined words
 	//
+	var userDictNotes map[string]string
 	if *userDictPath != "" {
-		userDict, err := readUserDict(*userDictPath)
+		userDict, disabled, notes, err := readUserDict(*userDictPath, *locale)
 		if err != nil {
 			log.Fatalf("reading user defined corrections: %v", err)
 		}
 
 		r.AddRuleList(userDict)
+		r.RemoveRule(disabled)
+		userDictNotes = notes
 	}
 
 	//
@@ -158,17 +209,29 @@ func main() {
 	//
 	// Custom output format
 	//
-	var err error
 	defaultWrite, defaultRead, err = createTemplates(*format)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	switch {
+	case *hunspellDict != "" && *format == "":
+		defaultWrite = template.Must(template.New("hunspellRead").Parse(hunspellReadTmpl))
+		defaultRead = defaultWrite
+	case *suggestFlag && *format == "":
+		defaultWrite = template.Must(template.New("suggestRead").Parse(suggestReadTmpl))
+		defaultRead = defaultWrite
+	}
+
 	switch *format {
 	case outputFormatCSV:
 		output.Println(csvHeader)
 	case outputFormatSQLite, outputFormatSQLite3:
 		output.Println(sqliteHeader)
+	case outputFormatSarif:
+		// SARIF is a single JSON document: results are buffered across
+		// every file and emitted once processing finishes, so there is
+		// no streaming header to print here.
 	}
 
 	// Done with Flags.
@@ -201,6 +264,7 @@ func main() {
 		}
 
 		count := 0
+		var sarifDiffs []misspell.Diff
 		next := func(diff misspell.Diff) {
 			count++
 
@@ -210,6 +274,12 @@ func main() {
 			}
 
 			diff.Filename = "stdin"
+			diff.Note = userDictNotes[diff.Corrected]
+
+			if *format == outputFormatSarif {
+				sarifDiffs = append(sarifDiffs, diff)
+				return
+			}
 
 			if *writeit {
 				defaultWrite.Execute(errOut, diff)
@@ -228,6 +298,10 @@ func main() {
 		switch *format {
 		case outputFormatSQLite, outputFormatSQLite3:
 			fileOut.Write([]byte(sqliteFooter))
+		case outputFormatSarif:
+			if !*quietFlag {
+				misspell.WriteSarif(errOut, misspell.NewSarifLog(version, sarifDiffs))
+			}
 		}
 
 		if count != 0 && *exitError {
@@ -241,8 +315,14 @@ func main() {
 	c := make(chan string, 64)
 	results := make(chan int, *workers)
 
+	var sarifResults chan []misspell.Diff
+	if *format == outputFormatSarif {
+		sarifResults = make(chan []misspell.Diff, *workers)
+	}
+
 	for range *workers {
-		go worker(*writeit, &r, *mode, c, results)
+		go worker(*writeit, &r, *mode, scope, hunspell, *suggestFlag, *format, userDictNotes, c, results, sarifResults)
+
 	}
 
 	for _, filename := range args {
@@ -256,14 +336,23 @@ func main() {
 	close(c)
 
 	count := 0
+	var sarifDiffs []misspell.Diff
 	for range *workers {
 		changed := <-results
 		count += changed
+
+		if sarifResults != nil {
+			sarifDiffs = append(sarifDiffs, <-sarifResults...)
+		}
 	}
 
 	switch *format {
 	case outputFormatSQLite, outputFormatSQLite3:
 		output.Println(sqliteFooter)
+	case outputFormatSarif:
+		if !*quietFlag {
+			misspell.WriteSarif(output.Writer(), misspell.NewSarifLog(version, sarifDiffs))
+		}
 	}
 
 	if count != 0 && *exitError {
@@ -271,8 +360,9 @@ func main() {
 	}
 }
 
-func worker(writeit bool, r *misspell.Replacer, mode string, files <-chan string, results chan<- int) {
+func worker(writeit bool, r *misspell.Replacer, mode string, scope misspell.GoScope, hunspell *misspell.HunspellDict, suggest bool, format string, notes map[string]string, files <-chan string, results chan<- int, sarifResults chan<- []misspell.Diff) {
 	count := 0
+	var sarifDiffs []misspell.Diff
 	for filename := range files {
 		orig, err := misspell.ReadTextFile(filename)
 		if err != nil {
@@ -289,9 +379,21 @@ func worker(writeit bool, r *misspell.Replacer, mode string, files <-chan string
 		var updated string
 		var changes []misspell.Diff
 
-		if mode == "go" {
-			updated, changes = r.ReplaceGo(orig)
-		} else {
+		switch {
+		case hunspell != nil:
+			// Hunspell mode is list-only: report unknown tokens rather
+			// than auto-correcting a fixed typo table, so there is
+			// never a rewritten copy to write back out.
+			updated, changes = orig, hunspell.CheckText(orig)
+		case suggest:
+			updated, changes = r.ReplaceSuggest(orig, writeit)
+		case mode == "go":
+			updated, changes, err = r.ReplaceGoScoped(filename, []byte(orig), scope)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+		default:
 			updated, changes = r.Replace(orig)
 		}
 
@@ -304,6 +406,15 @@ func worker(writeit bool, r *misspell.Replacer, mode string, files <-chan string
 		for _, diff := range changes {
 			// add in filename
 			diff.Filename = filename
+			diff.Note = notes[diff.Corrected]
+
+			if format == outputFormatSarif {
+				// SARIF is a single JSON document emitted once at the
+				// end, so just accumulate; each worker's slice is
+				// merged by the caller once every file is processed.
+				sarifDiffs = append(sarifDiffs, diff)
+				continue
+			}
 
 			// Output can be done by doing multiple goroutines
 			// and can clobber os.Stdout.
@@ -325,29 +436,46 @@ func worker(writeit bool, r *misspell.Replacer, mode string, files <-chan string
 		}
 	}
 	results <- count
+
+	if sarifResults != nil {
+		sarifResults <- sarifDiffs
+	}
 }
 
-func readUserDict(userDictPath string) ([]string, error) {
-	file, err := os.Open(userDictPath)
+// readUserDict loads a -dict file. Structured dictionaries (.json,
+// .toml, .yaml/.yml) may list multiple typos per correction, a note
+// surfaced via {{ .Note }}, a locale restriction, and a top-level
+// disabled list; anything else is treated as the legacy 2-column CSV.
+func readUserDict(userDictPath, locale string) (rules, disabled []string, notes map[string]string, err error) {
+	data, err := os.ReadFile(userDictPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load user defined corrections %q: %w", userDictPath, err)
+		return nil, nil, nil, fmt.Errorf("failed to load user defined corrections %q: %w", userDictPath, err)
 	}
-	defer func() { _ = file.Close() }()
 
-	reader := csv.NewReader(file)
-	reader.FieldsPerRecord = 2
+	if !misspell.IsStructuredUserDict(userDictPath) {
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.FieldsPerRecord = 2
 
-	data, err := reader.ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("reading user defined corrections: %w", err)
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("reading user defined corrections: %w", err)
+		}
+
+		for _, row := range rows {
+			rules = append(rules, row...)
+		}
+
+		return rules, nil, nil, nil
 	}
 
-	var userDict []string
-	for _, row := range data {
-		userDict = append(userDict, row...)
+	userDict, err := misspell.ParseUserDict(data, filepath.Ext(userDictPath))
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
-	return userDict, nil
+	rules, notes = userDict.RuleList(locale)
+
+	return rules, userDict.Disabled, notes, nil
 }
 
 func createTemplates(format string) (writeTmpl, readTmpl *template.Template, err error) {
@@ -360,6 +488,11 @@ func createTemplates(format string) (writeTmpl, readTmpl *template.Template, err
 		tmpl := template.Must(template.New(outputFormatSQLite3).Parse(sqliteTmpl))
 		return tmpl, tmpl, nil
 
+	case format == outputFormatSarif:
+		// SARIF results are built directly from misspell.Diff via
+		// misspell.NewSarifLog, not through the template system.
+		return nil, nil, nil
+
 	case format != "":
 		tmpl, err := template.New("custom").Parse(format)
 		if err != nil {
@@ -0,0 +1,333 @@
+package misspell
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GoScope selects which parts of a Go source file ReplaceGoScoped should
+// look at. It is parsed from the comma-separated -go-scope flag value.
+type GoScope struct {
+	Comments    bool // ordinary //, /* */ comments (minus //go: directives and cgo preambles)
+	Strings     bool // string literals (minus struct tags and import paths)
+	Identifiers bool // identifier occurrences anywhere in the file
+	Names       bool // doc comments attached to exported top-level declarations
+}
+
+// ParseGoScope parses a comma-separated list of "comments", "strings",
+// "identifiers", and "names" into a GoScope. An empty string means the
+// historical default: comments only.
+func ParseGoScope(s string) (GoScope, error) {
+	var scope GoScope
+
+	if s == "" {
+		scope.Comments = true
+		return scope, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		switch strings.TrimSpace(part) {
+		case "comments":
+			scope.Comments = true
+		case "strings":
+			scope.Strings = true
+		case "identifiers":
+			scope.Identifiers = true
+		case "names":
+			scope.Names = true
+		default:
+			return scope, fmt.Errorf("unknown -go-scope value %q", part)
+		}
+	}
+
+	return scope, nil
+}
+
+// edit is a single byte-range splice into the original source.
+type edit struct {
+	start, end int
+	text       string
+}
+
+// ReplaceGoScoped scans a Go source file using go/parser instead of
+// treating it as plain text, limited to the requested scope. Unlike
+// Replacer.Replace, rewrites are applied as byte-offset splices against
+// the original source -- nothing is reformatted, so gofmt/goimports
+// output is left untouched.
+func (r *Replacer) ReplaceGoScoped(filename string, src []byte, scope GoScope) (string, []Diff, error) {
+	fset := token.NewFileSet()
+
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return string(src), nil, fmt.Errorf("parsing %s: %w", filename, err)
+	}
+
+	skip := taggedOrImportedLiterals(f)
+	cgoPreamble := cgoPreambleComments(f)
+
+	var edits []edit
+	var diffs []Diff
+
+	addNode := func(pos, end token.Pos, text string) {
+		corrected, local := r.Replace(text)
+		if len(local) == 0 {
+			return
+		}
+
+		start := fset.Position(pos)
+		for _, d := range local {
+			d.Line, d.Column = adjustPosition(start, d.Line, d.Column)
+			diffs = append(diffs, d)
+		}
+
+		edits = append(edits, edit{start: fset.Position(pos).Offset, end: fset.Position(end).Offset, text: corrected})
+	}
+
+	// Comments and Names can both select the same doc comment (Names is
+	// a subset of Comments: the doc comments of exported declarations),
+	// so track which *ast.Comment nodes have already been queued to
+	// avoid reporting -- and rewriting -- the same typo twice.
+	seenComment := make(map[*ast.Comment]bool)
+
+	visitComment := func(c *ast.Comment) {
+		if seenComment[c] || strings.HasPrefix(c.Text, "//go:") || cgoPreamble[c] {
+			return
+		}
+
+		seenComment[c] = true
+		addNode(c.Pos(), c.End(), c.Text)
+	}
+
+	if scope.Comments {
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				visitComment(c)
+			}
+		}
+	}
+
+	if scope.Names {
+		for _, decl := range f.Decls {
+			doc, name := declDoc(decl)
+			if doc == nil || name == "" || !ast.IsExported(name) {
+				continue
+			}
+
+			for _, c := range doc.List {
+				visitComment(c)
+			}
+		}
+	}
+
+	if scope.Strings {
+		ast.Inspect(f, func(n ast.Node) bool {
+			lit, ok := n.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING || skip[lit] {
+				return true
+			}
+
+			addNode(lit.Pos(), lit.End(), lit.Value)
+
+			return true
+		})
+	}
+
+	if scope.Identifiers {
+		ast.Inspect(f, func(n ast.Node) bool {
+			id, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+
+			for _, w := range identifierWords(id.Name) {
+				addNode(id.Pos()+token.Pos(w.start), id.Pos()+token.Pos(w.end), w.text)
+			}
+
+			return true
+		})
+	}
+
+	return splice(src, edits), diffs, nil
+}
+
+// adjustPosition converts a Line/Column pair that Replacer.Replace
+// computed relative to a standalone snippet into file-absolute
+// coordinates, given the snippet's starting position in the file.
+func adjustPosition(start token.Position, line, col int) (int, int) {
+	if line == 1 {
+		return start.Line, start.Column + col - 1
+	}
+
+	return start.Line + line - 1, col
+}
+
+// splice applies non-overlapping byte-range edits to src and returns
+// the result. Edits are sorted by start offset first, since
+// ReplaceGoScoped collects them in one pass per scope rather than in
+// source order.
+func splice(src []byte, edits []edit) string {
+	if len(edits) == 0 {
+		return string(src)
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var out strings.Builder
+	out.Grow(len(src))
+
+	last := 0
+	for _, e := range edits {
+		if e.start < last {
+			// overlapping edit (shouldn't happen for disjoint AST nodes); skip it
+			continue
+		}
+
+		out.Write(src[last:e.start])
+		out.WriteString(e.text)
+		last = e.end
+	}
+
+	out.Write(src[last:])
+
+	return out.String()
+}
+
+// taggedOrImportedLiterals returns the set of *ast.BasicLit string
+// literals that must never be spell-checked as prose: struct field
+// tags and import paths.
+func taggedOrImportedLiterals(f *ast.File) map[*ast.BasicLit]bool {
+	skip := make(map[*ast.BasicLit]bool)
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.Field:
+			if node.Tag != nil {
+				skip[node.Tag] = true
+			}
+		case *ast.ImportSpec:
+			skip[node.Path] = true
+		}
+
+		return true
+	})
+
+	return skip
+}
+
+// cgoPreambleComments returns the set of comments making up the cgo
+// preamble: the comment group immediately preceding `import "C"`.
+func cgoPreambleComments(f *ast.File) map[*ast.Comment]bool {
+	skip := make(map[*ast.Comment]bool)
+
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			imp := spec.(*ast.ImportSpec)
+			if imp.Path.Value != `"C"` {
+				continue
+			}
+
+			if gd.Doc != nil {
+				for _, c := range gd.Doc.List {
+					skip[c] = true
+				}
+			}
+		}
+	}
+
+	return skip
+}
+
+// declDoc returns the doc comment and primary name for a top-level
+// declaration, if any. For GenDecls with a single spec (the common
+// case for a doc-commented type/var/const), the spec's own name is
+// used; grouped declarations fall back to no name since the doc
+// comment doesn't belong to one single identifier.
+func declDoc(decl ast.Decl) (*ast.CommentGroup, string) {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Doc == nil || d.Name == nil {
+			return d.Doc, ""
+		}
+
+		return d.Doc, d.Name.Name
+	case *ast.GenDecl:
+		if d.Doc == nil || len(d.Specs) != 1 {
+			return d.Doc, ""
+		}
+
+		switch spec := d.Specs[0].(type) {
+		case *ast.TypeSpec:
+			return d.Doc, spec.Name.Name
+		case *ast.ValueSpec:
+			if len(spec.Names) == 1 {
+				return d.Doc, spec.Names[0].Name
+			}
+		}
+	}
+
+	return nil, ""
+}
+
+// identifierWord is one word-like run inside a compound identifier,
+// given as a byte offset range into the identifier's own name.
+type identifierWord struct {
+	start, end int
+	text       string
+}
+
+// identifierWords splits a Go identifier into its camelCase/PascalCase/
+// snake_case sub-words so each can be spell-checked on its own --
+// Replacer.Replace only recognizes single-case words (see CaseStyle),
+// so checking a whole compound identifier like "RecieveData" against it
+// directly never matches anything.
+func identifierWords(name string) []identifierWord {
+	r := []rune(name)
+
+	// offs[i] is the byte offset of rune i in name.
+	offs := make([]int, len(r)+1)
+	for i, c := range r {
+		offs[i+1] = offs[i] + utf8.RuneLen(c)
+	}
+
+	var words []identifierWord
+	start := 0
+
+	flush := func(end int) {
+		if end > start && r[start] != '_' {
+			words = append(words, identifierWord{start: offs[start], end: offs[end], text: string(r[start:end])})
+		}
+	}
+
+	for i := 1; i < len(r); i++ {
+		switch {
+		case r[i] == '_':
+			flush(i)
+			start = i + 1
+		case unicode.IsUpper(r[i]) && unicode.IsLower(r[i-1]):
+			// ...eiv|Data -> boundary before an uppercase letter that
+			// follows a lowercase one.
+			flush(i)
+			start = i
+		case unicode.IsUpper(r[i]) && i+1 < len(r) && unicode.IsLower(r[i+1]) && unicode.IsUpper(r[i-1]):
+			// HTTP|Server -> boundary before the last letter of a run of
+			// uppercase letters when it starts a new capitalized word.
+			flush(i)
+			start = i
+		}
+	}
+
+	flush(len(r))
+
+	return words
+}
@@ -0,0 +1,98 @@
+package misspell
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReplaceGoScopedNoDoubleReportOnCommentsAndNames(t *testing.T) {
+	src := `package p
+
+// Foo recieves a value.
+func Foo() {}
+`
+
+	r := &Replacer{Replacements: []string{"recieves", "receives"}}
+	r.Compile()
+
+	scope, err := ParseGoScope("comments,names")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, diffs, err := r.ReplaceGoScoped("p.go", []byte(src), scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	for _, d := range diffs {
+		if d.Original == "recieves" {
+			count++
+		}
+	}
+
+	if count != 1 {
+		t.Errorf("expected exactly 1 diff for the shared doc comment typo, got %d", count)
+	}
+}
+
+func TestReplaceGoScopedIdentifiersCompoundWord(t *testing.T) {
+	src := `package p
+
+func RecieveData() {}
+`
+
+	r := &Replacer{Replacements: []string{"recieve", "receive"}}
+	r.Compile()
+
+	scope, err := ParseGoScope("identifiers")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, diffs, err := r.ReplaceGoScoped("p.go", []byte(src), scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diffs) != 1 || diffs[0].Original != "Recieve" {
+		t.Fatalf("expected 1 diff on the \"Recieve\" sub-word, got %+v", diffs)
+	}
+
+	if !strings.Contains(out, "func ReceiveData()") {
+		t.Errorf("expected compound identifier to be corrected in place, got %s", out)
+	}
+}
+
+func TestIdentifierWords(t *testing.T) {
+	tests := map[string][]string{
+		"RecieveData": {"Recieve", "Data"},
+		"HTTPServer":  {"HTTP", "Server"},
+		"user_id":     {"user", "id"},
+		"Recieve":     {"Recieve"},
+	}
+
+	for name, want := range tests {
+		words := identifierWords(name)
+		if len(words) != len(want) {
+			t.Fatalf("identifierWords(%q) = %v, want %v", name, words, want)
+		}
+		for i, w := range want {
+			if words[i].text != w {
+				t.Errorf("identifierWords(%q)[%d] = %q, want %q", name, i, words[i].text, w)
+			}
+		}
+	}
+}
+
+func TestParseGoScopeDefault(t *testing.T) {
+	scope, err := ParseGoScope("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !scope.Comments || scope.Strings || scope.Identifiers || scope.Names {
+		t.Errorf("default scope should be comments-only, got %+v", scope)
+	}
+}
@@ -0,0 +1,514 @@
+package misspell
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// affAffix is a single PFX/SFX entry: strip the suffix/prefix matching
+// strip (or "0" for nothing), add the replacement, and only apply when
+// the remainder of the word matches condition (a Hunspell regex-lite
+// condition, "." meaning any).
+type affAffix struct {
+	strip     string
+	add       string
+	condition string
+	flags     string // continuation class flags appended to the derived word
+}
+
+// affClass is one PFX or SFX block, keyed by its single-character (or
+// long/numeric) flag.
+type affClass struct {
+	suffix       bool // true for SFX, false for PFX
+	crossProduct bool
+	entries      []affAffix
+}
+
+// AffData is the parsed form of a Hunspell .aff affix file: the handful
+// of header directives misspell understands plus the PFX/SFX tables
+// needed to expand .dic roots into their inflected forms.
+type AffData struct {
+	Set       string // SET, e.g. "UTF-8"
+	Try       string // TRY, letter frequency hint; unused for expansion
+	FlagType  string // FLAG, one of "", "long", "num", "UTF-8"
+	WordChars string // WORDCHARS, extra characters allowed inside a word
+	ICONV     []affConv
+	OCONV     []affConv
+	Classes   map[string]*affClass
+}
+
+type affConv struct {
+	from, to string
+}
+
+// ParseAff parses a Hunspell .aff file. Only the directives misspell
+// needs to tokenize words and expand the dictionary are interpreted;
+// everything else (REP, MAP, compounding, …) is ignored.
+func ParseAff(r io.Reader) (*AffData, error) {
+	aff := &AffData{FlagType: "", Classes: map[string]*affClass{}}
+
+	scanner := bufio.NewScanner(r)
+	var cur *affClass
+	var curFlag string
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "SET":
+			if len(fields) > 1 {
+				aff.Set = fields[1]
+			}
+		case "TRY":
+			if len(fields) > 1 {
+				aff.Try = fields[1]
+			}
+		case "FLAG":
+			if len(fields) > 1 {
+				aff.FlagType = fields[1]
+			}
+		case "WORDCHARS":
+			if len(fields) > 1 {
+				aff.WordChars = fields[1]
+			}
+		case "ICONV":
+			if len(fields) >= 3 && fields[1] != "ICONV" {
+				// header line "ICONV <count>" -- skip, entries follow
+				if _, err := fmt.Sscanf(fields[1], "%d", new(int)); err != nil {
+					aff.ICONV = append(aff.ICONV, affConv{from: fields[1], to: fields[2]})
+				}
+			}
+		case "OCONV":
+			if len(fields) >= 3 {
+				if _, err := fmt.Sscanf(fields[1], "%d", new(int)); err != nil {
+					aff.OCONV = append(aff.OCONV, affConv{from: fields[1], to: fields[2]})
+				}
+			}
+		case "PFX", "SFX":
+			switch len(fields) {
+			case 4:
+				// class header: PFX <flag> <crossProduct Y/N> <count>
+				curFlag = fields[1]
+				cur = &affClass{suffix: fields[0] == "SFX", crossProduct: fields[2] == "Y"}
+				aff.Classes[curFlag] = cur
+			case 5:
+				// entry: PFX <flag> <strip> <add[/flags]> <condition>
+				if cur == nil || fields[1] != curFlag {
+					continue
+				}
+				add, flags, _ := strings.Cut(fields[3], "/")
+				strip := fields[2]
+				if strip == "0" {
+					strip = ""
+				}
+				if add == "0" {
+					add = ""
+				}
+				cur.entries = append(cur.entries, affAffix{
+					strip:     strip,
+					add:       add,
+					condition: fields[4],
+					flags:     flags,
+				})
+			}
+		}
+	}
+
+	return aff, scanner.Err()
+}
+
+// DicEntry is one line of a .dic file: a root word and the affix flags
+// that apply to it.
+type DicEntry struct {
+	Root  string
+	Flags []string
+}
+
+// ParseDic parses a Hunspell .dic file. The first line (word count) is
+// skipped; each remaining line is "root[/flags] [# morphological fields]".
+func ParseDic(r io.Reader, aff *AffData) ([]DicEntry, error) {
+	scanner := bufio.NewScanner(r)
+
+	// first line is the approximate word count; ignore it
+	if scanner.Scan() {
+		// no-op, just consume
+	}
+
+	var entries []DicEntry
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// morphological fields (st:..., ph:...) come after whitespace; drop them
+		line = strings.Fields(line)[0]
+
+		root, flagStr, _ := strings.Cut(line, "/")
+		entries = append(entries, DicEntry{Root: applyConv(root, aff.ICONV), Flags: splitFlags(flagStr, aff.FlagType)})
+	}
+
+	return entries, scanner.Err()
+}
+
+// applyConv runs word through a sequence of ICONV/OCONV character
+// conversions, applied in file order.
+func applyConv(word string, convs []affConv) string {
+	for _, c := range convs {
+		word = strings.ReplaceAll(word, c.from, c.to)
+	}
+
+	return word
+}
+
+func splitFlags(s, flagType string) []string {
+	if s == "" {
+		return nil
+	}
+
+	switch flagType {
+	case "long":
+		var out []string
+		for i := 0; i+1 < len(s); i += 2 {
+			out = append(out, s[i:i+2])
+		}
+		return out
+	case "num":
+		return strings.Split(s, ",")
+	default: // single-character flags (including UTF-8)
+		return strings.Split(s, "")
+	}
+}
+
+// matchesCondition reports whether the end of stem (after stripping)
+// satisfies a Hunspell affix condition, where "." matches anything.
+func matchesCondition(stem, condition string) bool {
+	if condition == "." || condition == "" {
+		return true
+	}
+
+	re, err := regexp.Compile(condition + "$")
+	if err != nil {
+		return true
+	}
+
+	return re.MatchString(stem)
+}
+
+// maxAffixDepth bounds how many continuation-class affixes can chain
+// off a single root (e.g. plural -s then possessive -'s), guarding
+// against malformed .aff files with cyclical continuation flags.
+const maxAffixDepth = 4
+
+// Expand applies every PFX/SFX class referenced by each dictionary
+// entry's flags and returns the full set of inflected word forms,
+// including the unmodified roots themselves. Two Hunspell mechanisms
+// are honored beyond a single flat affix application: continuation
+// classes (an affix entry's own flags chain into further PFX/SFX
+// rules) and cross products (a crossProduct-enabled SFX rule and a
+// crossProduct-enabled PFX rule both applying to the same root, as
+// PFX+root+SFX).
+func Expand(entries []DicEntry, aff *AffData) map[string]struct{} {
+	words := make(map[string]struct{}, len(entries)*2)
+
+	for _, e := range entries {
+		addWord(words, aff, e.Root)
+		applyChain(words, aff, e.Root, e.Flags, maxAffixDepth)
+		applyCrossProduct(words, aff, e.Root, e.Flags)
+	}
+
+	return words
+}
+
+// addWord records a derived form, running it through OCONV first.
+func addWord(words map[string]struct{}, aff *AffData, word string) {
+	words[applyConv(word, aff.OCONV)] = struct{}{}
+}
+
+// applyChain recursively applies every PFX/SFX class referenced by
+// flags to word, following each entry's own continuation flags (if
+// any) into further affix applications up to depth levels deep.
+func applyChain(words map[string]struct{}, aff *AffData, word string, flags []string, depth int) {
+	if depth <= 0 {
+		return
+	}
+
+	for _, flag := range flags {
+		class, ok := aff.Classes[flag]
+		if !ok {
+			continue
+		}
+
+		for _, a := range class.entries {
+			derived, ok := applyAffix(word, class.suffix, a)
+			if !ok {
+				continue
+			}
+
+			addWord(words, aff, derived)
+
+			if a.flags != "" {
+				applyChain(words, aff, derived, splitFlags(a.flags, aff.FlagType), depth-1)
+			}
+		}
+	}
+}
+
+// applyCrossProduct combines one crossProduct-enabled SFX rule with
+// one crossProduct-enabled PFX rule on the same root, since Hunspell
+// treats that combination (PFX+root+SFX) as valid independent of
+// continuation-class chaining.
+func applyCrossProduct(words map[string]struct{}, aff *AffData, root string, flags []string) {
+	for _, sflag := range flags {
+		sclass := aff.Classes[sflag]
+		if sclass == nil || !sclass.suffix || !sclass.crossProduct {
+			continue
+		}
+
+		for _, sa := range sclass.entries {
+			suffixed, ok := applyAffix(root, true, sa)
+			if !ok {
+				continue
+			}
+
+			for _, pflag := range flags {
+				pclass := aff.Classes[pflag]
+				if pclass == nil || pclass.suffix || !pclass.crossProduct {
+					continue
+				}
+
+				for _, pa := range pclass.entries {
+					if final, ok := applyAffix(suffixed, false, pa); ok {
+						addWord(words, aff, final)
+					}
+				}
+			}
+		}
+	}
+}
+
+func applyAffix(root string, suffix bool, a affAffix) (string, bool) {
+	if suffix {
+		stem := strings.TrimSuffix(root, a.strip)
+		if !strings.HasSuffix(root, a.strip) || !matchesCondition(stem, a.condition) {
+			return "", false
+		}
+
+		return stem + a.add, true
+	}
+
+	stem := strings.TrimPrefix(root, a.strip)
+	if !strings.HasPrefix(root, a.strip) || !matchesCondition(stem, a.condition) {
+		return "", false
+	}
+
+	return a.add + stem, true
+}
+
+// HunspellDict is an expanded Hunspell dictionary: the full set of
+// known word forms, ready for O(1) lookups.
+type HunspellDict struct {
+	Aff   *AffData
+	Words map[string]struct{}
+}
+
+// LoadHunspellDict searches each directory in searchPath (colon
+// separated, as in $PATH) for "<name>.aff" and "<name>.dic", parses
+// them, and expands the dictionary into its full word list.
+func LoadHunspellDict(searchPath, name string) (*HunspellDict, error) {
+	for _, dir := range strings.Split(searchPath, ":") {
+		if dir == "" {
+			dir = "."
+		}
+
+		affPath := filepath.Join(dir, name+".aff")
+		dicPath := filepath.Join(dir, name+".dic")
+
+		affFile, err := os.Open(affPath)
+		if err != nil {
+			continue
+		}
+
+		aff, err := ParseAff(affFile)
+		affFile.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", affPath, err)
+		}
+
+		dicFile, err := os.Open(dicPath)
+		if err != nil {
+			return nil, fmt.Errorf("opening %s: %w", dicPath, err)
+		}
+
+		entries, err := ParseDic(dicFile, aff)
+		dicFile.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", dicPath, err)
+		}
+
+		return &HunspellDict{Aff: aff, Words: Expand(entries, aff)}, nil
+	}
+
+	return nil, fmt.Errorf("hunspell dictionary %q not found in %q", name, searchPath)
+}
+
+// AddPersonalWordList reads a one-word-per-line personal wordlist (the
+// format used by `-p` with real hunspell/ispell) and merges it into d.
+func (d *HunspellDict) AddPersonalWordList(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("reading personal wordlist %q: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+
+		// personal dictionaries may prefix a word with "*" to mark it
+		// as a forbidden (always-wrong) word; misspell only cares
+		// about the allow-list case here, so such entries are skipped.
+		if strings.HasPrefix(word, "*") {
+			continue
+		}
+		d.Words[word] = struct{}{}
+	}
+
+	return scanner.Err()
+}
+
+// Check reports whether word is a known form in the dictionary. A word
+// stored all-lowercase in the dictionary also matches its Title and
+// UPPER forms, mirroring hunspell's capitalization handling for those
+// common cases; a dictionary word with capitals matches only as stored.
+func (d *HunspellDict) Check(word string) bool {
+	if _, ok := d.Words[word]; ok {
+		return true
+	}
+
+	if _, ok := d.Words[strings.ToLower(word)]; ok {
+		return true
+	}
+
+	return false
+}
+
+var wordRe = regexp.MustCompile(`[\p{L}'’]+`)
+
+// Suggest returns a single best-effort correction for word, restricted
+// to the expanded lexicon, using edit-distance-1 candidates (insert,
+// delete, substitute, and adjacent transposition). It returns "" when
+// no such candidate is a known word.
+func (d *HunspellDict) Suggest(word string) string {
+	lower := strings.ToLower(word)
+
+	best := ""
+	for _, candidate := range editDistance1(lower) {
+		if _, ok := d.Words[candidate]; !ok {
+			continue
+		}
+
+		if best == "" || candidate < best {
+			best = candidate
+		}
+	}
+
+	return best
+}
+
+var editLetters = []rune("abcdefghijklmnopqrstuvwxyz'")
+
+// editDistance1 generates every string reachable from word by one
+// insertion, deletion, substitution, or adjacent transposition.
+func editDistance1(word string) []string {
+	r := []rune(word)
+	var out []string
+
+	for i := range r {
+		// deletion
+		out = append(out, string(r[:i])+string(r[i+1:]))
+
+		// substitution
+		for _, c := range editLetters {
+			if c == r[i] {
+				continue
+			}
+
+			out = append(out, string(r[:i])+string(c)+string(r[i+1:]))
+		}
+
+		// adjacent transposition
+		if i+1 < len(r) {
+			swapped := append([]rune{}, r...)
+			swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+			out = append(out, string(swapped))
+		}
+	}
+
+	// insertion at every position, including the end
+	for i := 0; i <= len(r); i++ {
+		for _, c := range editLetters {
+			out = append(out, string(r[:i])+string(c)+string(r[i:]))
+		}
+	}
+
+	return out
+}
+
+// CheckText scans text for tokens unknown to the dictionary and
+// returns one Diff per token, same shape as Replacer.Replace so it can
+// flow through the existing output templates. Corrected is filled in
+// with Suggest's best edit-distance-1 candidate when one is found, so
+// the default templates ({{ .Corrected }}) render something useful
+// even in this list-only mode; it is left empty when no candidate in
+// the expanded lexicon is within distance 1.
+func (d *HunspellDict) CheckText(text string) []Diff {
+	var diffs []Diff
+
+	line, col := 1, 1
+	last := 0
+
+	for _, loc := range wordRe.FindAllStringIndex(text, -1) {
+		// advance line/col counters over the gap since the last match
+		for i := last; i < loc[0]; i++ {
+			if text[i] == '\n' {
+				line++
+				col = 1
+			} else {
+				col++
+			}
+		}
+		last = loc[0]
+
+		word := text[loc[0]:loc[1]]
+		if d.Check(word) {
+			col += loc[1] - loc[0]
+			last = loc[1]
+			continue
+		}
+
+		diffs = append(diffs, Diff{
+			Original:  word,
+			Corrected: d.Suggest(word),
+			Line:      line,
+			Column:    col,
+		})
+
+		col += loc[1] - loc[0]
+		last = loc[1]
+	}
+
+	return diffs
+}
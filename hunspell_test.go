@@ -0,0 +1,78 @@
+package misspell
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandContinuationChain(t *testing.T) {
+	aff := &AffData{
+		Classes: map[string]*affClass{
+			"A": {suffix: true, entries: []affAffix{{add: "s", condition: ".", flags: "B"}}},
+			"B": {suffix: true, entries: []affAffix{{add: "!", condition: ".", flags: ""}}},
+		},
+	}
+
+	words := make(map[string]struct{})
+	applyChain(words, aff, "cat", []string{"A"}, maxAffixDepth)
+
+	for _, want := range []string{"cats", "cats!"} {
+		if _, ok := words[want]; !ok {
+			t.Errorf("expected chained form %q in %v", want, words)
+		}
+	}
+}
+
+func TestExpandCrossProduct(t *testing.T) {
+	aff := &AffData{
+		Classes: map[string]*affClass{
+			"P": {suffix: false, crossProduct: true, entries: []affAffix{{add: "un", condition: "."}}},
+			"S": {suffix: true, crossProduct: true, entries: []affAffix{{add: "ed", condition: "."}}},
+		},
+	}
+
+	words := make(map[string]struct{})
+	applyCrossProduct(words, aff, "do", []string{"P", "S"})
+
+	if _, ok := words["undoed"]; !ok {
+		t.Errorf("expected cross-product form \"undoed\" in %v", words)
+	}
+}
+
+func TestParseDicAppliesIconv(t *testing.T) {
+	aff := &AffData{Classes: map[string]*affClass{}, ICONV: []affConv{{from: "oe", to: "ö"}}}
+
+	entries, err := ParseDic(strings.NewReader("1\nkoeln\n"), aff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].Root != "köln" {
+		t.Errorf("ICONV not applied, got %+v", entries)
+	}
+}
+
+func TestHunspellDictSuggest(t *testing.T) {
+	d := &HunspellDict{Words: map[string]struct{}{"the": {}, "hello": {}}}
+
+	if got := d.Suggest("teh"); got != "the" {
+		t.Errorf("Suggest(%q) = %q, want %q", "teh", got, "the")
+	}
+
+	if got := d.Suggest("zzzzzzz"); got != "" {
+		t.Errorf("Suggest(%q) = %q, want empty", "zzzzzzz", got)
+	}
+}
+
+func TestCheckTextPopulatesCorrected(t *testing.T) {
+	d := &HunspellDict{Words: map[string]struct{}{"the": {}}}
+
+	diffs := d.CheckText("teh")
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 diff, got %d", len(diffs))
+	}
+
+	if diffs[0].Corrected != "the" {
+		t.Errorf("Corrected = %q, want %q", diffs[0].Corrected, "the")
+	}
+}
@@ -44,6 +44,21 @@ func main() {
 			Comment: "converts UK spellings to US spellings",
 			Path:    "internal/gen/sources/us.json",
 		},
+		"words_nz.go": {
+			Name:    "NewZealand",
+			Comment: "converts other spellings to New Zealand spellings",
+			Path:    "internal/gen/sources/nz.json",
+		},
+		"words_au.go": {
+			Name:    "Australian",
+			Comment: "converts other spellings to Australian spellings",
+			Path:    "internal/gen/sources/au.json",
+		},
+		"words_ca.go": {
+			Name:    "Canadian",
+			Comment: "converts other spellings to Canadian spellings",
+			Path:    "internal/gen/sources/ca.json",
+		},
 	}
 
 	for dest, src := range dictionaries {
@@ -0,0 +1,26 @@
+package misspell
+
+// Diff is a single correction or suggestion found while scanning text:
+// where it was found, what was there, and what it should probably be.
+// Replacer.Replace, Replacer.ReplaceGoScoped, Replacer.ReplaceSuggest,
+// and HunspellDict.CheckText all return Diffs in this same shape so
+// they can share one set of output templates.
+type Diff struct {
+	Filename  string
+	Line      int
+	Column    int
+	Original  string
+	Corrected string
+
+	// Suggestions holds every ranked candidate considered for Original,
+	// most likely first, when the Diff came from Replacer.ReplaceSuggest;
+	// Corrected is always Suggestions[0] when both are set. It is nil
+	// for diffs produced by the deterministic typo table or Hunspell's
+	// dictionary check, which only ever have one candidate.
+	Suggestions []string
+
+	// Note is an optional rationale carried over from a structured
+	// -dict entry's "note" field, keyed by correction; empty unless the
+	// correction came from one.
+	Note string
+}
@@ -0,0 +1,146 @@
+package misspell
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"unicode/utf8"
+)
+
+// sarifVersion is the SARIF schema version misspell emits.
+const sarifVersion = "2.1.0"
+
+const sarifSchema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+// SarifLog is the top-level SARIF document. Only the subset of the
+// schema misspell needs to produce a viewer-friendly report is
+// modeled here.
+type SarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type SarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           SarifRegion           `json:"region"`
+}
+
+type SarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndColumn   int `json:"endColumn"`
+}
+
+// NewSarifLog builds a complete SARIF 2.1.0 document from every Diff
+// found across a run. Unlike the line-based CSV/SQLite templates,
+// SARIF is a single JSON document, so callers must buffer every Diff
+// until the run is complete before calling this.
+func NewSarifLog(version string, diffs []Diff) SarifLog {
+	rules := make(map[string]bool)
+	results := make([]SarifResult, 0, len(diffs))
+
+	for _, d := range diffs {
+		rules[d.Corrected] = true
+
+		results = append(results, SarifResult{
+			RuleID: d.Corrected,
+			Level:  "note",
+			Message: SarifMessage{
+				Text: fmt.Sprintf("%q is a misspelling of %q", d.Original, d.Corrected),
+			},
+			Locations: []SarifLocation{{
+				PhysicalLocation: SarifPhysicalLocation{
+					ArtifactLocation: SarifArtifactLocation{URI: toFileURI(d.Filename)},
+					Region: SarifRegion{
+						StartLine:   d.Line,
+						StartColumn: d.Column,
+						EndColumn:   d.Column + utf8.RuneCountInString(d.Original),
+					},
+				},
+			}},
+		})
+	}
+
+	ruleNames := make([]string, 0, len(rules))
+	for name := range rules {
+		ruleNames = append(ruleNames, name)
+	}
+
+	sort.Strings(ruleNames)
+
+	sarifRules := make([]SarifRule, 0, len(ruleNames))
+	for _, name := range ruleNames {
+		sarifRules = append(sarifRules, SarifRule{ID: name, Name: name})
+	}
+
+	return SarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []SarifRun{{
+			Tool: SarifTool{
+				Driver: SarifDriver{
+					Name:           "misspell",
+					Version:        version,
+					InformationURI: "https://github.com/golangci/misspell",
+					Rules:          sarifRules,
+				},
+			},
+			Results: results,
+		}},
+	}
+}
+
+// WriteSarif marshals a SarifLog as indented JSON to w.
+func WriteSarif(w io.Writer, log SarifLog) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(log)
+}
+
+func toFileURI(path string) string {
+	return (&url.URL{Path: path}).String()
+}
@@ -0,0 +1,46 @@
+package misspell
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewSarifLog(t *testing.T) {
+	diffs := []Diff{
+		{Filename: "foo.txt", Original: "teh", Corrected: "the", Line: 3, Column: 5},
+	}
+
+	log := NewSarifLog("1.2.3", diffs)
+
+	if log.Schema != sarifSchema || log.Version != sarifVersion {
+		t.Fatalf("unexpected schema/version: %+v", log)
+	}
+
+	if len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("expected 1 run with 1 result, got %+v", log.Runs)
+	}
+
+	result := log.Runs[0].Results[0]
+	if result.RuleID != "the" {
+		t.Errorf("RuleID = %q, want %q", result.RuleID, "the")
+	}
+
+	loc := result.Locations[0].PhysicalLocation
+	if loc.Region.StartLine != 3 || loc.Region.StartColumn != 5 || loc.Region.EndColumn != 8 {
+		t.Errorf("unexpected region: %+v", loc.Region)
+	}
+}
+
+func TestWriteSarif(t *testing.T) {
+	log := NewSarifLog("1.2.3", []Diff{{Filename: "foo.txt", Original: "teh", Corrected: "the"}})
+
+	var buf bytes.Buffer
+	if err := WriteSarif(&buf, log); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), `"ruleId": "the"`) {
+		t.Errorf("expected encoded SARIF to contain ruleId, got %s", buf.String())
+	}
+}
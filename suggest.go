@@ -0,0 +1,349 @@
+package misspell
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// embeddedCommonWords is a small built-in frequency-ordered lexicon of
+// common English words, used as the known-good word list when no
+// Hunspell dictionary is loaded. It is deliberately compact: pair
+// -suggest with -hunspell-dict for full-language coverage.
+var embeddedCommonWords = []string{
+	"the", "be", "to", "of", "and", "a", "in", "that", "have", "it",
+	"for", "not", "on", "with", "he", "as", "you", "do", "at", "this",
+	"but", "his", "by", "from", "they", "we", "say", "her", "she", "or",
+	"an", "will", "my", "one", "all", "would", "there", "their", "what",
+	"so", "up", "out", "if", "about", "who", "get", "which", "go", "me",
+	"when", "make", "can", "like", "time", "no", "just", "him", "know",
+	"take", "people", "into", "year", "your", "good", "some", "could",
+	"them", "see", "other", "than", "then", "now", "look", "only",
+	"come", "its", "over", "think", "also", "back", "after", "use",
+	"two", "how", "our", "work", "first", "well", "way", "even", "new",
+	"want", "because", "any", "these", "give", "day", "most", "us",
+}
+
+// symDeleteIndex is a Symmetric Delete spelling index: every lexicon
+// word is reduced to all of its delete-variants up to maxDist, and
+// each variant is bucketed to the words it came from. A query is
+// reduced the same way, and the union of matched buckets gives the
+// candidate set to rescore by true edit distance.
+type symDeleteIndex struct {
+	maxDist int
+	buckets map[string][]string
+	known   map[string]bool
+	freq    map[string]int
+}
+
+func newSymDeleteIndex(words []string, maxDist int) *symDeleteIndex {
+	idx := &symDeleteIndex{
+		maxDist: maxDist,
+		buckets: make(map[string][]string),
+		known:   make(map[string]bool),
+		freq:    make(map[string]int),
+	}
+
+	for _, w := range words {
+		w = strings.ToLower(w)
+		if w == "" || idx.known[w] {
+			idx.freq[w]++
+			continue
+		}
+
+		idx.known[w] = true
+		idx.freq[w]++
+
+		for variant := range deleteVariants(w, maxDist) {
+			idx.buckets[variant] = append(idx.buckets[variant], w)
+		}
+	}
+
+	return idx
+}
+
+// deleteVariants returns the set of strings reachable from w by
+// deleting up to dist characters (including w itself).
+func deleteVariants(w string, dist int) map[string]bool {
+	variants := map[string]bool{w: true}
+	frontier := []string{w}
+
+	for d := 0; d < dist; d++ {
+		var next []string
+
+		for _, s := range frontier {
+			for i := range s {
+				v := s[:i] + s[i+1:]
+				if !variants[v] {
+					variants[v] = true
+					next = append(next, v)
+				}
+			}
+		}
+
+		frontier = next
+	}
+
+	return variants
+}
+
+// damerauLevenshtein returns the Damerau-Levenshtein edit distance
+// between a and b (insert, delete, substitute, and adjacent
+// transposition each cost one).
+func damerauLevenshtein(a, b string) int {
+	da := make(map[byte]int)
+
+	maxDist := len(a) + len(b)
+	d := make([][]int, len(a)+2)
+	for i := range d {
+		d[i] = make([]int, len(b)+2)
+	}
+
+	d[0][0] = maxDist
+	for i := 0; i <= len(a); i++ {
+		d[i+1][0] = maxDist
+		d[i+1][1] = i
+	}
+
+	for j := 0; j <= len(b); j++ {
+		d[0][j+1] = maxDist
+		d[1][j+1] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		db := 0
+
+		for j := 1; j <= len(b); j++ {
+			i1 := da[b[j-1]]
+			j1 := db
+
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+				db = j
+			}
+
+			d[i+1][j+1] = min4(
+				d[i][j]+cost,
+				d[i+1][j]+1,
+				d[i][j+1]+1,
+				d[i1][j1]+(i-i1-1)+1+(j-j1-1),
+			)
+		}
+
+		da[a[i-1]] = i
+	}
+
+	return d[len(a)+1][len(b)+1]
+}
+
+func min4(a, b, c, d int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	if d < m {
+		m = d
+	}
+
+	return m
+}
+
+// candidates returns up to topN known words within maxDist true edit
+// distance of word, ranked by distance then by observed frequency.
+func (idx *symDeleteIndex) candidates(original string, topN int) []string {
+	word := strings.ToLower(original)
+	if idx.known[word] {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var pool []string
+
+	for variant := range deleteVariants(word, idx.maxDist) {
+		for _, w := range idx.buckets[variant] {
+			if !seen[w] {
+				seen[w] = true
+				pool = append(pool, w)
+			}
+		}
+	}
+
+	type scored struct {
+		word string
+		dist int
+		freq int
+	}
+
+	var ranked []scored
+	for _, w := range pool {
+		dist := damerauLevenshtein(word, w)
+		if dist > idx.maxDist {
+			continue
+		}
+
+		ranked = append(ranked, scored{word: w, dist: dist, freq: idx.freq[w]})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].dist != ranked[j].dist {
+			return ranked[i].dist < ranked[j].dist
+		}
+		if ranked[i].freq != ranked[j].freq {
+			return ranked[i].freq > ranked[j].freq
+		}
+
+		return ranked[i].word < ranked[j].word
+	})
+
+	if len(ranked) == 0 {
+		return nil
+	}
+
+	if len(ranked) > topN {
+		ranked = ranked[:topN]
+	}
+
+	out := make([]string, len(ranked))
+	for i, s := range ranked {
+		out[i] = matchCase(original, s.word)
+	}
+
+	return out
+}
+
+// matchCase reapplies original's casing pattern to candidate: an
+// all-uppercase original (e.g. "TEH") upper-cases candidate, and an
+// original starting with an uppercase letter (e.g. "Teh") title-cases
+// it; anything else is returned as-is, since candidate is already
+// lower-case from the lexicon. This keeps a suggestion for a
+// capitalized typo at a sentence start from introducing a new casing
+// error when auto-applied.
+func matchCase(original, candidate string) string {
+	runes := []rune(original)
+
+	firstLetter := -1
+	allUpper := true
+	for i, r := range runes {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if firstLetter == -1 {
+			firstLetter = i
+		}
+		if !unicode.IsUpper(r) {
+			allUpper = false
+		}
+	}
+
+	if firstLetter == -1 {
+		return candidate
+	}
+
+	if allUpper {
+		return strings.ToUpper(candidate)
+	}
+
+	if unicode.IsUpper(runes[firstLetter]) {
+		out := []rune(candidate)
+		out[0] = unicode.ToUpper(out[0])
+		return string(out)
+	}
+
+	return candidate
+}
+
+var suggestIndexes sync.Map // map[*Replacer]*symDeleteIndex
+
+// lexicon returns (building and caching it on first use) the set of
+// known-good words for r: every correction already in r's rule table
+// plus the embedded common-word list.
+func (r *Replacer) lexicon() *symDeleteIndex {
+	if v, ok := suggestIndexes.Load(r); ok {
+		return v.(*symDeleteIndex)
+	}
+
+	words := append([]string{}, embeddedCommonWords...)
+	for i := 1; i < len(r.Replacements); i += 2 {
+		words = append(words, r.Replacements[i])
+	}
+
+	idx := newSymDeleteIndex(words, 2)
+	suggestIndexes.Store(r, idx)
+
+	return idx
+}
+
+// Suggest returns up to 5 ranked spelling candidates for word, drawn
+// from r's known-good lexicon (see lexicon), or nil if word is itself
+// recognized or no candidate is within edit distance 2.
+func (r *Replacer) Suggest(word string) []string {
+	return r.lexicon().candidates(word, 5)
+}
+
+// ReplaceSuggest is the -suggest mode entry point: every word in text
+// is checked against r's deterministic typo table first (same as
+// Replace) and, for anything that table doesn't recognize and isn't
+// already a known-good word, against Suggest's ranked candidates.
+// When autoApply is true, a word with exactly one suggestion above
+// threshold is corrected in the returned text; anything with more than
+// one candidate is always report-only, since auto-applying an
+// ambiguous guess would be worse than leaving the typo in place.
+func (r *Replacer) ReplaceSuggest(text string, autoApply bool) (string, []Diff) {
+	var diffs []Diff
+	var edits []edit
+
+	for _, loc := range wordRe.FindAllStringIndex(text, -1) {
+		word := text[loc[0]:loc[1]]
+
+		if corrected, local := r.Replace(word); len(local) > 0 {
+			d := local[0]
+			d.Line, d.Column = lineColAt(text, loc[0])
+			diffs = append(diffs, d)
+			edits = append(edits, edit{start: loc[0], end: loc[1], text: corrected})
+
+			continue
+		}
+
+		suggestions := r.Suggest(word)
+		if len(suggestions) == 0 {
+			continue
+		}
+
+		line, col := lineColAt(text, loc[0])
+		diffs = append(diffs, Diff{
+			Original:    word,
+			Corrected:   suggestions[0],
+			Suggestions: suggestions,
+			Line:        line,
+			Column:      col,
+		})
+
+		if autoApply && len(suggestions) == 1 {
+			edits = append(edits, edit{start: loc[0], end: loc[1], text: suggestions[0]})
+		}
+	}
+
+	return splice([]byte(text), edits), diffs
+}
+
+// lineColAt returns the 1-based line and column of byte offset in
+// text.
+func lineColAt(text string, offset int) (line, col int) {
+	line, col = 1, 1
+
+	for i := 0; i < offset; i++ {
+		if text[i] == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+
+	return line, col
+}
@@ -0,0 +1,54 @@
+package misspell
+
+import "testing"
+
+func TestSuggestPreservesCase(t *testing.T) {
+	idx := newSymDeleteIndex([]string{"the"}, 2)
+
+	cases := map[string]string{
+		"teh": "the",
+		"Teh": "The",
+		"TEH": "THE",
+	}
+
+	for word, want := range cases {
+		got := idx.candidates(word, 5)
+		if len(got) != 1 || got[0] != want {
+			t.Errorf("candidates(%q) = %v, want [%q]", word, got, want)
+		}
+	}
+}
+
+func TestReplaceSuggestPopulatesSuggestions(t *testing.T) {
+	r := &Replacer{Replacements: []string{"the", "the"}}
+	r.Compile()
+
+	_, diffs := r.ReplaceSuggest("you want too see it", false)
+
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 diff, got %d: %+v", len(diffs), diffs)
+	}
+
+	if len(diffs[0].Suggestions) == 0 {
+		t.Errorf("expected Suggestions to be populated for %q, got none", diffs[0].Original)
+	}
+
+	if diffs[0].Corrected != diffs[0].Suggestions[0] {
+		t.Errorf("Corrected = %q, want top suggestion %q", diffs[0].Corrected, diffs[0].Suggestions[0])
+	}
+}
+
+func TestMatchCase(t *testing.T) {
+	tests := []struct{ original, candidate, want string }{
+		{"teh", "the", "the"},
+		{"Teh", "the", "The"},
+		{"TEH", "the", "THE"},
+		{"123", "the", "the"},
+	}
+
+	for _, tc := range tests {
+		if got := matchCase(tc.original, tc.candidate); got != tc.want {
+			t.Errorf("matchCase(%q, %q) = %q, want %q", tc.original, tc.candidate, got, tc.want)
+		}
+	}
+}
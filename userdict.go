@@ -0,0 +1,108 @@
+package misspell
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// UserDictEntry is one reviewed correction in a structured user
+// dictionary: one or more typos that should all be corrected to the
+// same word, with an optional rationale and an optional locale
+// restriction.
+type UserDictEntry struct {
+	Typos      []string `json:"typos"                 toml:"typos"                 yaml:"typos"`
+	Correction string   `json:"correction"             toml:"correction"            yaml:"correction"`
+	Note       string   `json:"note,omitempty"         toml:"note,omitempty"        yaml:"note,omitempty"`
+	Locales    []string `json:"locales,omitempty"      toml:"locales,omitempty"     yaml:"locales,omitempty"`
+}
+
+// UserDict is the structured form of a -dict file: a list of reviewed
+// corrections plus a set of built-in corrections to switch off.
+type UserDict struct {
+	Corrections []UserDictEntry `json:"corrections"        toml:"corrections"        yaml:"corrections"`
+	Disabled    []string        `json:"disabled,omitempty" toml:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+// ParseUserDict decodes a structured user dictionary. The format is
+// chosen by ext, which should be the file's extension including the
+// leading dot (".json", ".toml", ".yaml" or ".yml").
+func ParseUserDict(data []byte, ext string) (*UserDict, error) {
+	var dict UserDict
+
+	switch strings.ToLower(ext) {
+	case ".json":
+		if err := json.Unmarshal(data, &dict); err != nil {
+			return nil, fmt.Errorf("parsing JSON user dictionary: %w", err)
+		}
+	case ".toml":
+		if err := toml.Unmarshal(data, &dict); err != nil {
+			return nil, fmt.Errorf("parsing TOML user dictionary: %w", err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &dict); err != nil {
+			return nil, fmt.Errorf("parsing YAML user dictionary: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported user dictionary extension %q", ext)
+	}
+
+	return &dict, nil
+}
+
+// IsStructuredUserDict reports whether path's extension indicates a
+// structured (JSON/TOML/YAML) user dictionary rather than the legacy
+// two-column CSV format.
+func IsStructuredUserDict(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".toml", ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// appliesToLocale reports whether an entry's locale restriction (if
+// any) matches the active -locale value. No restriction means the
+// entry always applies.
+func (e UserDictEntry) appliesToLocale(locale string) bool {
+	if len(e.Locales) == 0 {
+		return true
+	}
+
+	for _, l := range e.Locales {
+		if strings.EqualFold(l, locale) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RuleList flattens a UserDict into the typo/correction pair list
+// AddRuleList expects, dropping any entry whose locales restriction
+// doesn't match the active locale, and returns the notes keyed by
+// correction so they can be attached to Diffs as they're produced.
+func (d *UserDict) RuleList(locale string) (rules []string, notes map[string]string) {
+	notes = make(map[string]string)
+
+	for _, entry := range d.Corrections {
+		if !entry.appliesToLocale(locale) {
+			continue
+		}
+
+		for _, typo := range entry.Typos {
+			rules = append(rules, typo, entry.Correction)
+		}
+
+		if entry.Note != "" {
+			notes[entry.Correction] = entry.Note
+		}
+	}
+
+	return rules, notes
+}
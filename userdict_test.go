@@ -0,0 +1,56 @@
+package misspell
+
+import "testing"
+
+func TestParseUserDictJSON(t *testing.T) {
+	data := []byte(`{
+		"corrections": [
+			{"typos": ["teh", "hte"], "correction": "the", "note": "common keyboard slip"},
+			{"typos": ["colour"], "correction": "color", "locales": ["us"]}
+		],
+		"disabled": ["recieve"]
+	}`)
+
+	dict, err := ParseUserDict(data, ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(dict.Corrections) != 2 || len(dict.Disabled) != 1 {
+		t.Fatalf("unexpected parse result: %+v", dict)
+	}
+
+	rules, notes := dict.RuleList("")
+	want := []string{"teh", "the", "hte", "the"}
+	if len(rules) != len(want) {
+		t.Fatalf("RuleList(\"\") = %v, want %v", rules, want)
+	}
+	for i := range want {
+		if rules[i] != want[i] {
+			t.Errorf("rules[%d] = %q, want %q", i, rules[i], want[i])
+		}
+	}
+
+	if notes["the"] != "common keyboard slip" {
+		t.Errorf("notes[the] = %q, want %q", notes["the"], "common keyboard slip")
+	}
+}
+
+func TestUserDictRuleListLocaleFiltering(t *testing.T) {
+	dict := &UserDict{
+		Corrections: []UserDictEntry{
+			{Typos: []string{"colour"}, Correction: "color", Locales: []string{"us"}},
+			{Typos: []string{"color"}, Correction: "colour", Locales: []string{"uk"}},
+		},
+	}
+
+	rules, _ := dict.RuleList("us")
+	if len(rules) != 2 || rules[0] != "colour" || rules[1] != "color" {
+		t.Errorf("RuleList(\"us\") = %v, want only the US-locale entry", rules)
+	}
+
+	rules, _ = dict.RuleList("ca")
+	if len(rules) != 0 {
+		t.Errorf("RuleList(\"ca\") = %v, want no entries to apply", rules)
+	}
+}
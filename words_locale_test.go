@@ -0,0 +1,57 @@
+package misspell
+
+import "testing"
+
+// localeDicts are the locale-specific correction tables added alongside
+// the built-in US/UK pair; each is a flat typo/correction pair list like
+// DictMain, generated by internal/gen from internal/gen/sources/*.json.
+var localeDicts = map[string][]string{
+	"DictNewZealand": DictNewZealand,
+	"DictAustralian": DictAustralian,
+	"DictCanadian":   DictCanadian,
+}
+
+func Test_locale_word_sort(t *testing.T) {
+	for name, dict := range localeDicts {
+		for i := 0; i+3 < len(dict); i += 2 {
+			if len(dict[i]) < len(dict[i+2]) {
+				t.Errorf("%s: entry %q (len %d) is shorter than the following entry %q (len %d); want longest-first", name, dict[i], len(dict[i]), dict[i+2], len(dict[i+2]))
+			}
+		}
+	}
+}
+
+func Test_locale_consistent_dictionaries(t *testing.T) {
+	for name, dict := range localeDicts {
+		if len(dict)%2 != 0 {
+			t.Fatalf("%s: odd number of entries, not typo/correction pairs", name)
+		}
+
+		seen := make(map[string]bool)
+		for i := 0; i < len(dict); i += 2 {
+			typo, correction := dict[i], dict[i+1]
+
+			if typo == correction {
+				t.Errorf("%s: %q corrects to itself", name, typo)
+			}
+
+			if seen[typo] {
+				t.Errorf("%s: duplicate typo %q", name, typo)
+			}
+			seen[typo] = true
+		}
+	}
+}
+
+// Test_locale_no_program_programme guards against a rule that fires on
+// ordinary uses of the word "program" in code or docs; upstream's own
+// uk.json deliberately omits it for the same reason.
+func Test_locale_no_program_programme(t *testing.T) {
+	for name, dict := range localeDicts {
+		for i := 0; i < len(dict); i += 2 {
+			if dict[i] == "program" {
+				t.Errorf("%s: %q should not be corrected to %q", name, dict[i], dict[i+1])
+			}
+		}
+	}
+}